@@ -0,0 +1,21 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubeadm contains the internal, hub API of kubeadm. All the versioned
+// APIs (v1alpha2, v1alpha3, ...) convert to and from this package, which is
+// never serialized directly to disk.
+// +k8s:deepcopy-gen=package
+package kubeadm // import "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
@@ -0,0 +1,56 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scheme contains the scheme and codec factory that all of kubeadm's typed config
+// handling (loading, defaulting, converting, marshaling) is built on top of. Every versioned
+// kubeadm API package registers itself here via its AddToScheme function.
+package scheme
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1alpha2"
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1alpha3"
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta1"
+)
+
+// Scheme is the runtime.Scheme to which all kubeadm api types are registered.
+var Scheme = runtime.NewScheme()
+
+// Codecs provides methods for retrieving codecs and serializers for specific versions and
+// content types.
+var Codecs = serializer.NewCodecFactory(Scheme)
+
+func init() {
+	AddToScheme(Scheme)
+}
+
+// AddToScheme builds the kubeadm scheme using all known versions of the kubeadm api.
+func AddToScheme(scheme *runtime.Scheme) {
+	utilruntimeMust(kubeadm.AddToScheme(scheme))
+	utilruntimeMust(v1alpha2.AddToScheme(scheme))
+	utilruntimeMust(v1alpha3.AddToScheme(scheme))
+	utilruntimeMust(v1beta1.AddToScheme(scheme))
+	utilruntimeMust(scheme.SetVersionPriority(v1beta1.SchemeGroupVersion, v1alpha3.SchemeGroupVersion, v1alpha2.SchemeGroupVersion))
+}
+
+func utilruntimeMust(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InitConfiguration contains a list of elements that is specific "kubeadm init"-only runtime
+// information: node-local bootstrap data that is never uploaded to the cluster. ClusterConfiguration
+// is embedded so that "kubeadm init" callers have a single object to work with; it is not inlined
+// into InitConfiguration's own wire format (see its json tag) because kubeadm persists it to the
+// cluster as its own, separate document.
+type InitConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// BootstrapTokens is respected at "kubeadm init" time and describes a set of Bootstrap Tokens to
+	// create. This information IS NOT uploaded to the kubeadm cluster configmap, partly because of
+	// its sensitive nature.
+	BootstrapTokens []BootstrapToken `json:"bootstrapTokens,omitempty"`
+
+	// NodeRegistration holds fields that relate to registering the new master node to the cluster.
+	NodeRegistration NodeRegistrationOptions `json:"nodeRegistration,omitempty"`
+
+	// LocalAPIEndpoint represents the endpoint of the API server instance that's deployed on this
+	// control-plane node. In HA setups, this differs from ClusterConfiguration.ControlPlaneEndpoint,
+	// which is the shared endpoint for the entire control plane.
+	LocalAPIEndpoint APIEndpoint `json:"localAPIEndpoint,omitempty"`
+
+	// ClusterConfiguration holds the cluster-wide configuration and is the only part of this object
+	// that kubeadm uploads to the cluster. It is not serialized as a nested field of InitConfiguration;
+	// callers that want both on disk write it out as its own, separate "---"-joined document.
+	ClusterConfiguration `json:"-"`
+}
+
+// ClusterConfiguration contains cluster-wide configuration for a kubeadm cluster, shared by every
+// control-plane and joining node; unlike InitConfiguration it is uploaded to, and read back from,
+// the kubeadm cluster configmap.
+type ClusterConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ClusterName is the cluster name.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// KubernetesVersion is the target version of the control plane.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// APIServerExtraArgs is a set of extra flags to pass to the API Server or override
+	// default ones in form of <flagname,value>.
+	APIServerExtraArgs map[string]string `json:"apiServerExtraArgs,omitempty"`
+	// ControllerManagerExtraArgs is a set of extra flags to pass to the Controller Manager
+	// or override default ones in form of <flagname,value>.
+	ControllerManagerExtraArgs map[string]string `json:"controllerManagerExtraArgs,omitempty"`
+	// SchedulerExtraArgs is a set of extra flags to pass to the Scheduler or override
+	// default ones in form of <flagname,value>.
+	SchedulerExtraArgs map[string]string `json:"schedulerExtraArgs,omitempty"`
+
+	// APIServerCertSANs sets extra Subject Alternative Names for the API Server signing cert.
+	APIServerCertSANs []string `json:"apiServerCertSANs,omitempty"`
+	// CertificatesDir specifies where to store or look for all required certificates.
+	CertificatesDir string `json:"certificatesDir,omitempty"`
+	// ImageRepository sets the container registry to pull control plane images from.
+	ImageRepository string `json:"imageRepository,omitempty"`
+	// UnifiedControlPlaneImage specifies if a specific container image should be used for all
+	// control plane components.
+	UnifiedControlPlaneImage string `json:"unifiedControlPlaneImage,omitempty"`
+
+	// FeatureGates enabled by the user.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// Etcd holds configuration for etcd.
+	Etcd Etcd `json:"etcd,omitempty"`
+	// Networking holds configuration for the networking topology of the cluster.
+	Networking Networking `json:"networking,omitempty"`
+
+	// ComponentConfigs holds component configuration documents (for example KubeletConfiguration or
+	// KubeProxyConfiguration) that were read alongside this object from a multi-document kubeadm
+	// config file, keyed by their Kind. kubeadm does not interpret these, it merely carries them
+	// through so that callers like "kubeadm init phase kubelet-start" can consume them.
+	ComponentConfigs ComponentConfigMap `json:"componentConfigs,omitempty"`
+}
+
+// ComponentConfigMap holds arbitrary component configuration documents, keyed by their Kind.
+type ComponentConfigMap map[string]runtime.RawExtension
+
+// BootstrapToken describes one bootstrap token, stored as a Secret in the cluster.
+type BootstrapToken struct {
+	// Token is used for establishing bidirectional trust between nodes and control-planes.
+	// Used for both the "kubeadm init" bootstrap token as well as the "kubeadm join" discovery token.
+	Token string `json:"token"`
+	// Description sets a human-friendly message why this token exists and what it's used for.
+	Description string `json:"description,omitempty"`
+	// TTL defines the time to live for this token. Defaults to 24h.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// Usages describes the ways in which this token can be used.
+	Usages []string `json:"usages,omitempty"`
+	// Groups specifies the extra groups that this token will authenticate as when/if used for
+	// authentication.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// NodeRegistrationOptions holds fields that relate to registering a new control-plane or node to
+// the cluster, either via "kubeadm init" or "kubeadm join".
+type NodeRegistrationOptions struct {
+	// Name is the `.Metadata.Name` field of the Node API object that will be created in this
+	// "kubeadm init" or "kubeadm join" operation. Config defaulting leaves it empty if unset.
+	Name string `json:"name,omitempty"`
+	// CRISocket is used to retrieve container runtime info. Config defaulting leaves it empty
+	// if unset.
+	CRISocket string `json:"criSocket,omitempty"`
+	// Taints specifies the taints the Node API object should be registered with. Defaults to
+	// an empty list.
+	Taints []v1.Taint `json:"taints,omitempty"`
+	// KubeletExtraArgs passes through extra arguments to the kubelet. The arguments here are
+	// passed to the kubelet command line via the environment file kubeadm writes at runtime
+	// for the kubelet to source.
+	KubeletExtraArgs map[string]string `json:"kubeletExtraArgs,omitempty"`
+}
+
+// APIEndpoint struct contains elements of API server instance deployed on a node.
+type APIEndpoint struct {
+	// AdvertiseAddress sets the IP address for the API server to advertise.
+	AdvertiseAddress string `json:"advertiseAddress,omitempty"`
+	// BindPort sets the secure port for the API Server to bind to. Defaults to 6443.
+	BindPort int32 `json:"bindPort,omitempty"`
+}
+
+// Etcd contains elements describing Etcd configuration.
+type Etcd struct {
+	// Local provides configuration knobs for the built-in etcd instance that kubeadm manages.
+	// Local and External are mutually exclusive.
+	Local *LocalEtcd `json:"local,omitempty"`
+	// External describes how to connect to an external etcd cluster. Local and External are
+	// mutually exclusive.
+	External *ExternalEtcd `json:"external,omitempty"`
+}
+
+// LocalEtcd describes that kubeadm should run an etcd cluster locally.
+type LocalEtcd struct {
+	// Image specifies which container image to use for running etcd. Config defaulting leaves
+	// it empty if unset.
+	Image string `json:"image,omitempty"`
+	// DataDir is the directory etcd will place its data. Config defaulting leaves it empty if
+	// unset.
+	DataDir string `json:"dataDir"`
+	// ExtraArgs are extra arguments provided to the etcd binary when run inside a static pod.
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+	// ServerCertSANs sets extra Subject Alternative Names for the etcd server signing cert.
+	ServerCertSANs []string `json:"serverCertSANs,omitempty"`
+	// PeerCertSANs sets extra Subject Alternative Names for the etcd peer signing cert.
+	PeerCertSANs []string `json:"peerCertSANs,omitempty"`
+}
+
+// ExternalEtcd describes an external etcd cluster.
+type ExternalEtcd struct {
+	// Endpoints of etcd members.
+	Endpoints []string `json:"endpoints"`
+	// CAFile is an SSL Certificate Authority file used to secure etcd communication.
+	CAFile string `json:"caFile"`
+	// CertFile is an SSL certification file used to secure etcd communication.
+	CertFile string `json:"certFile"`
+	// KeyFile is an SSL key file used to secure etcd communication.
+	KeyFile string `json:"keyFile"`
+}
+
+// Networking contains elements describing cluster's networking configuration.
+type Networking struct {
+	// ServiceSubnet is the subnet used by k8s services. Defaults to "10.96.0.0/12".
+	ServiceSubnet string `json:"serviceSubnet,omitempty"`
+	// PodSubnet is the subnet used by pods.
+	PodSubnet string `json:"podSubnet,omitempty"`
+	// DNSDomain is the dns domain used by k8s services. Defaults to "cluster.local".
+	DNSDomain string `json:"dnsDomain,omitempty"`
+}
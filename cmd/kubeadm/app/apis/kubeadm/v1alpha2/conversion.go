@@ -0,0 +1,92 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/conversion"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+)
+
+// MasterConfigurationToInitConfiguration converts the node-local half of a v1alpha2
+// MasterConfiguration to the internal InitConfiguration, folding the flat Token*/NodeName/CRISocket
+// fields into the BootstrapTokens slice and NodeRegistration struct the internal type uses. Unlike
+// the other versions' Convert_* functions, this is not registered with the scheme: v1alpha2 is
+// rejected by util/config.ValidateSupportedVersion on the normal load path, and this function exists
+// only to back util/config.MigrateOldConfig.
+func MasterConfigurationToInitConfiguration(in *MasterConfiguration, out *kubeadm.InitConfiguration, s conversion.Scope) error {
+	out.LocalAPIEndpoint = kubeadm.APIEndpoint{
+		AdvertiseAddress: in.API.AdvertiseAddress,
+		BindPort:         in.API.BindPort,
+	}
+	out.NodeRegistration = kubeadm.NodeRegistrationOptions{
+		Name:      in.NodeName,
+		CRISocket: in.CRISocket,
+	}
+
+	if in.Token != "" || in.TokenTTL != nil || len(in.TokenUsages) > 0 || len(in.TokenGroups) > 0 {
+		out.BootstrapTokens = []kubeadm.BootstrapToken{
+			{
+				Token:  in.Token,
+				TTL:    in.TokenTTL,
+				Usages: in.TokenUsages,
+				Groups: in.TokenGroups,
+			},
+		}
+	}
+	return nil
+}
+
+// MasterConfigurationToClusterConfiguration converts the cluster-wide half of a v1alpha2
+// MasterConfiguration to the internal ClusterConfiguration. See MasterConfigurationToInitConfiguration
+// for why this is not registered with the scheme.
+func MasterConfigurationToClusterConfiguration(in *MasterConfiguration, out *kubeadm.ClusterConfiguration, s conversion.Scope) error {
+	out.ClusterName = in.ClusterName
+	out.KubernetesVersion = in.KubernetesVersion
+	out.APIServerExtraArgs = in.APIServerExtraArgs
+	out.ControllerManagerExtraArgs = in.ControllerManagerExtraArgs
+	out.SchedulerExtraArgs = in.SchedulerExtraArgs
+	out.APIServerCertSANs = in.APIServerCertSANs
+	out.CertificatesDir = in.CertificatesDir
+	out.ImageRepository = in.ImageRepository
+	out.UnifiedControlPlaneImage = in.UnifiedControlPlaneImage
+	out.FeatureGates = in.FeatureGates
+	out.Networking = kubeadm.Networking{
+		ServiceSubnet: in.Networking.ServiceSubnet,
+		PodSubnet:     in.Networking.PodSubnet,
+		DNSDomain:     in.Networking.DNSDomain,
+	}
+
+	if in.Etcd.Local != nil {
+		out.Etcd.Local = &kubeadm.LocalEtcd{
+			Image:          in.Etcd.Local.Image,
+			DataDir:        in.Etcd.Local.DataDir,
+			ExtraArgs:      in.Etcd.Local.ExtraArgs,
+			ServerCertSANs: in.Etcd.Local.ServerCertSANs,
+			PeerCertSANs:   in.Etcd.Local.PeerCertSANs,
+		}
+	}
+	if in.Etcd.External != nil {
+		out.Etcd.External = &kubeadm.ExternalEtcd{
+			Endpoints: in.Etcd.External.Endpoints,
+			CAFile:    in.Etcd.External.CAFile,
+			CertFile:  in.Etcd.External.CertFile,
+			KeyFile:   in.Etcd.External.KeyFile,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MasterConfiguration contains a list of elements that is specific "kubeadm init"-only runtime
+// information.
+type MasterConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	API        API        `json:"api"`
+	Etcd       Etcd       `json:"etcd"`
+	Networking Networking `json:"networking"`
+
+	KubernetesVersion string `json:"kubernetesVersion"`
+
+	NodeName  string `json:"nodeName"`
+	CRISocket string `json:"criSocket,omitempty"`
+
+	APIServerExtraArgs         map[string]string `json:"apiServerExtraArgs,omitempty"`
+	ControllerManagerExtraArgs map[string]string `json:"controllerManagerExtraArgs,omitempty"`
+	SchedulerExtraArgs         map[string]string `json:"schedulerExtraArgs,omitempty"`
+
+	APIServerCertSANs        []string `json:"apiServerCertSANs,omitempty"`
+	CertificatesDir          string   `json:"certificatesDir"`
+	ImageRepository          string   `json:"imageRepository"`
+	UnifiedControlPlaneImage string   `json:"unifiedControlPlaneImage,omitempty"`
+
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	ClusterName  string          `json:"clusterName,omitempty"`
+
+	// Token is used for establishing bidirectional trust between nodes and control-planes. Used
+	// for both the "kubeadm init" bootstrap token as well as the "kubeadm join" discovery token.
+	Token string `json:"token,omitempty"`
+	// TokenTTL defines the time to live for this token. Defaults to 24h.
+	TokenTTL *metav1.Duration `json:"tokenTTL,omitempty"`
+	// TokenUsages describes the ways in which this token can be used.
+	TokenUsages []string `json:"tokenUsages,omitempty"`
+	// TokenGroups specifies the extra groups that this token will authenticate as when used
+	// for authentication.
+	TokenGroups []string `json:"tokenGroups,omitempty"`
+}
+
+// API struct contains elements of API server address.
+type API struct {
+	AdvertiseAddress string `json:"advertiseAddress"`
+	BindPort         int32  `json:"bindPort"`
+}
+
+// Etcd contains elements describing Etcd configuration.
+type Etcd struct {
+	Local    *LocalEtcd    `json:"local,omitempty"`
+	External *ExternalEtcd `json:"external,omitempty"`
+}
+
+// LocalEtcd describes that kubeadm should run an etcd cluster locally.
+type LocalEtcd struct {
+	Image          string            `json:"image"`
+	DataDir        string            `json:"dataDir"`
+	ExtraArgs      map[string]string `json:"extraArgs,omitempty"`
+	ServerCertSANs []string          `json:"serverCertSANs,omitempty"`
+	PeerCertSANs   []string          `json:"peerCertSANs,omitempty"`
+}
+
+// ExternalEtcd describes an external etcd cluster.
+type ExternalEtcd struct {
+	Endpoints []string `json:"endpoints"`
+	CAFile    string   `json:"caFile"`
+	CertFile  string   `json:"certFile"`
+	KeyFile   string   `json:"keyFile"`
+}
+
+// Networking contains elements describing cluster's networking configuration.
+type Networking struct {
+	ServiceSubnet string `json:"serviceSubnet"`
+	PodSubnet     string `json:"podSubnet"`
+	DNSDomain     string `json:"dnsDomain"`
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MasterConfiguration contains a list of elements that is specific "kubeadm init"-only runtime
+// information.
+type MasterConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	BootstrapTokens  []BootstrapToken        `json:"bootstrapTokens,omitempty"`
+	NodeRegistration NodeRegistrationOptions `json:"nodeRegistration,omitempty"`
+	LocalAPIEndpoint APIEndpoint             `json:"localAPIEndpoint,omitempty"`
+
+	ClusterName       string `json:"clusterName,omitempty"`
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	APIServerExtraArgs         map[string]string `json:"apiServerExtraArgs,omitempty"`
+	ControllerManagerExtraArgs map[string]string `json:"controllerManagerExtraArgs,omitempty"`
+	SchedulerExtraArgs         map[string]string `json:"schedulerExtraArgs,omitempty"`
+
+	APIServerCertSANs        []string `json:"apiServerCertSANs,omitempty"`
+	CertificatesDir          string   `json:"certificatesDir,omitempty"`
+	ImageRepository          string   `json:"imageRepository,omitempty"`
+	UnifiedControlPlaneImage string   `json:"unifiedControlPlaneImage,omitempty"`
+
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	Etcd       Etcd       `json:"etcd,omitempty"`
+	Networking Networking `json:"networking,omitempty"`
+}
+
+// BootstrapToken describes one bootstrap token, stored as a Secret in the cluster.
+type BootstrapToken struct {
+	Token       string           `json:"token"`
+	Description string           `json:"description,omitempty"`
+	TTL         *metav1.Duration `json:"ttl,omitempty"`
+	Usages      []string         `json:"usages,omitempty"`
+	Groups      []string         `json:"groups,omitempty"`
+}
+
+// NodeRegistrationOptions holds fields that relate to registering a new control-plane or node to
+// the cluster, either via "kubeadm init" or "kubeadm join".
+type NodeRegistrationOptions struct {
+	Name             string            `json:"name,omitempty"`
+	CRISocket        string            `json:"criSocket,omitempty"`
+	Taints           []v1.Taint        `json:"taints,omitempty"`
+	KubeletExtraArgs map[string]string `json:"kubeletExtraArgs,omitempty"`
+}
+
+// APIEndpoint struct contains elements of API server instance deployed on a node.
+type APIEndpoint struct {
+	AdvertiseAddress string `json:"advertiseAddress,omitempty"`
+	BindPort         int32  `json:"bindPort,omitempty"`
+}
+
+// Etcd contains elements describing Etcd configuration.
+type Etcd struct {
+	Local    *LocalEtcd    `json:"local,omitempty"`
+	External *ExternalEtcd `json:"external,omitempty"`
+}
+
+// LocalEtcd describes that kubeadm should run an etcd cluster locally.
+type LocalEtcd struct {
+	Image          string            `json:"image,omitempty"`
+	DataDir        string            `json:"dataDir"`
+	ExtraArgs      map[string]string `json:"extraArgs,omitempty"`
+	ServerCertSANs []string          `json:"serverCertSANs,omitempty"`
+	PeerCertSANs   []string          `json:"peerCertSANs,omitempty"`
+}
+
+// ExternalEtcd describes an external etcd cluster.
+type ExternalEtcd struct {
+	Endpoints []string `json:"endpoints"`
+	CAFile    string   `json:"caFile"`
+	CertFile  string   `json:"certFile"`
+	KeyFile   string   `json:"keyFile"`
+}
+
+// Networking contains elements describing cluster's networking configuration.
+type Networking struct {
+	ServiceSubnet string `json:"serviceSubnet,omitempty"`
+	PodSubnet     string `json:"podSubnet,omitempty"`
+	DNSDomain     string `json:"dnsDomain,omitempty"`
+}
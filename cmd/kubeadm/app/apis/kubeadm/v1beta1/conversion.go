@@ -0,0 +1,171 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+)
+
+// addConversionFuncs registers the hand-written conversions between this version and the internal
+// kubeadm API.
+func addConversionFuncs(scheme *runtime.Scheme) error {
+	return scheme.AddConversionFuncs(
+		Convert_v1beta1_InitConfiguration_To_kubeadm_InitConfiguration,
+		Convert_kubeadm_InitConfiguration_To_v1beta1_InitConfiguration,
+		Convert_v1beta1_ClusterConfiguration_To_kubeadm_ClusterConfiguration,
+		Convert_kubeadm_ClusterConfiguration_To_v1beta1_ClusterConfiguration,
+	)
+}
+
+// Convert_v1beta1_InitConfiguration_To_kubeadm_InitConfiguration converts a v1beta1
+// InitConfiguration to its internal representation. The embedded ClusterConfiguration is left for
+// the caller to populate separately, since it is read from its own document.
+func Convert_v1beta1_InitConfiguration_To_kubeadm_InitConfiguration(in *InitConfiguration, out *kubeadm.InitConfiguration, s conversion.Scope) error {
+	out.LocalAPIEndpoint = kubeadm.APIEndpoint{
+		AdvertiseAddress: in.LocalAPIEndpoint.AdvertiseAddress,
+		BindPort:         in.LocalAPIEndpoint.BindPort,
+	}
+	out.NodeRegistration = kubeadm.NodeRegistrationOptions{
+		Name:             in.NodeRegistration.Name,
+		CRISocket:        in.NodeRegistration.CRISocket,
+		Taints:           in.NodeRegistration.Taints,
+		KubeletExtraArgs: in.NodeRegistration.KubeletExtraArgs,
+	}
+
+	out.BootstrapTokens = make([]kubeadm.BootstrapToken, len(in.BootstrapTokens))
+	for i := range in.BootstrapTokens {
+		out.BootstrapTokens[i] = kubeadm.BootstrapToken{
+			Token:       in.BootstrapTokens[i].Token,
+			Description: in.BootstrapTokens[i].Description,
+			TTL:         in.BootstrapTokens[i].TTL,
+			Usages:      in.BootstrapTokens[i].Usages,
+			Groups:      in.BootstrapTokens[i].Groups,
+		}
+	}
+	return nil
+}
+
+// Convert_kubeadm_InitConfiguration_To_v1beta1_InitConfiguration converts the internal
+// InitConfiguration back to v1beta1. The embedded ClusterConfiguration is marshaled by the caller
+// as its own document and is not touched here.
+func Convert_kubeadm_InitConfiguration_To_v1beta1_InitConfiguration(in *kubeadm.InitConfiguration, out *InitConfiguration, s conversion.Scope) error {
+	out.LocalAPIEndpoint = APIEndpoint{
+		AdvertiseAddress: in.LocalAPIEndpoint.AdvertiseAddress,
+		BindPort:         in.LocalAPIEndpoint.BindPort,
+	}
+	out.NodeRegistration = NodeRegistrationOptions{
+		Name:             in.NodeRegistration.Name,
+		CRISocket:        in.NodeRegistration.CRISocket,
+		Taints:           in.NodeRegistration.Taints,
+		KubeletExtraArgs: in.NodeRegistration.KubeletExtraArgs,
+	}
+
+	out.BootstrapTokens = make([]BootstrapToken, len(in.BootstrapTokens))
+	for i := range in.BootstrapTokens {
+		out.BootstrapTokens[i] = BootstrapToken{
+			Token:       in.BootstrapTokens[i].Token,
+			Description: in.BootstrapTokens[i].Description,
+			TTL:         in.BootstrapTokens[i].TTL,
+			Usages:      in.BootstrapTokens[i].Usages,
+			Groups:      in.BootstrapTokens[i].Groups,
+		}
+	}
+	return nil
+}
+
+// Convert_v1beta1_ClusterConfiguration_To_kubeadm_ClusterConfiguration converts a v1beta1
+// ClusterConfiguration to its internal representation.
+func Convert_v1beta1_ClusterConfiguration_To_kubeadm_ClusterConfiguration(in *ClusterConfiguration, out *kubeadm.ClusterConfiguration, s conversion.Scope) error {
+	out.ClusterName = in.ClusterName
+	out.KubernetesVersion = in.KubernetesVersion
+	out.APIServerExtraArgs = in.APIServerExtraArgs
+	out.ControllerManagerExtraArgs = in.ControllerManagerExtraArgs
+	out.SchedulerExtraArgs = in.SchedulerExtraArgs
+	out.APIServerCertSANs = in.APIServerCertSANs
+	out.CertificatesDir = in.CertificatesDir
+	out.ImageRepository = in.ImageRepository
+	out.UnifiedControlPlaneImage = in.UnifiedControlPlaneImage
+	out.FeatureGates = in.FeatureGates
+	out.Networking = kubeadm.Networking{
+		ServiceSubnet: in.Networking.ServiceSubnet,
+		PodSubnet:     in.Networking.PodSubnet,
+		DNSDomain:     in.Networking.DNSDomain,
+	}
+
+	out.Etcd = kubeadm.Etcd{}
+	if in.Etcd.Local != nil {
+		out.Etcd.Local = &kubeadm.LocalEtcd{
+			Image:          in.Etcd.Local.Image,
+			DataDir:        in.Etcd.Local.DataDir,
+			ExtraArgs:      in.Etcd.Local.ExtraArgs,
+			ServerCertSANs: in.Etcd.Local.ServerCertSANs,
+			PeerCertSANs:   in.Etcd.Local.PeerCertSANs,
+		}
+	}
+	if in.Etcd.External != nil {
+		out.Etcd.External = &kubeadm.ExternalEtcd{
+			Endpoints: in.Etcd.External.Endpoints,
+			CAFile:    in.Etcd.External.CAFile,
+			CertFile:  in.Etcd.External.CertFile,
+			KeyFile:   in.Etcd.External.KeyFile,
+		}
+	}
+	return nil
+}
+
+// Convert_kubeadm_ClusterConfiguration_To_v1beta1_ClusterConfiguration converts the internal
+// ClusterConfiguration back to v1beta1.
+func Convert_kubeadm_ClusterConfiguration_To_v1beta1_ClusterConfiguration(in *kubeadm.ClusterConfiguration, out *ClusterConfiguration, s conversion.Scope) error {
+	out.ClusterName = in.ClusterName
+	out.KubernetesVersion = in.KubernetesVersion
+	out.APIServerExtraArgs = in.APIServerExtraArgs
+	out.ControllerManagerExtraArgs = in.ControllerManagerExtraArgs
+	out.SchedulerExtraArgs = in.SchedulerExtraArgs
+	out.APIServerCertSANs = in.APIServerCertSANs
+	out.CertificatesDir = in.CertificatesDir
+	out.ImageRepository = in.ImageRepository
+	out.UnifiedControlPlaneImage = in.UnifiedControlPlaneImage
+	out.FeatureGates = in.FeatureGates
+	out.Networking = Networking{
+		ServiceSubnet: in.Networking.ServiceSubnet,
+		PodSubnet:     in.Networking.PodSubnet,
+		DNSDomain:     in.Networking.DNSDomain,
+	}
+
+	out.Etcd = Etcd{}
+	if in.Etcd.Local != nil {
+		out.Etcd.Local = &LocalEtcd{
+			Image:          in.Etcd.Local.Image,
+			DataDir:        in.Etcd.Local.DataDir,
+			ExtraArgs:      in.Etcd.Local.ExtraArgs,
+			ServerCertSANs: in.Etcd.Local.ServerCertSANs,
+			PeerCertSANs:   in.Etcd.Local.PeerCertSANs,
+		}
+	}
+	if in.Etcd.External != nil {
+		out.Etcd.External = &ExternalEtcd{
+			Endpoints: in.Etcd.External.Endpoints,
+			CAFile:    in.Etcd.External.CAFile,
+			CertFile:  in.Etcd.External.CertFile,
+			KeyFile:   in.Etcd.External.KeyFile,
+		}
+	}
+	return nil
+}
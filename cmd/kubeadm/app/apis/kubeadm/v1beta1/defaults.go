@@ -0,0 +1,77 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// DefaultServiceDNSDomain defines default cluster-internal domain name for Services.
+	DefaultServiceDNSDomain = "cluster.local"
+	// DefaultServicesSubnet defines default service subnet range.
+	DefaultServicesSubnet = "10.96.0.0/12"
+	// DefaultCertificatesDir defines default certificate directory.
+	DefaultCertificatesDir = "/etc/kubernetes/pki"
+	// DefaultImageRepository defines default image registry.
+	DefaultImageRepository = "k8s.gcr.io"
+	// DefaultAPIBindPort defines default API port.
+	DefaultAPIBindPort = 6443
+	// DefaultTokenDuration specifies the default amount of time that a bootstrap token will be
+	// valid. Used when a BootstrapToken's TTL is nil.
+	DefaultTokenDuration = 24 * time.Hour
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&InitConfiguration{}, func(obj interface{}) { SetDefaults_InitConfiguration(obj.(*InitConfiguration)) })
+	scheme.AddTypeDefaultingFunc(&ClusterConfiguration{}, func(obj interface{}) { SetDefaults_ClusterConfiguration(obj.(*ClusterConfiguration)) })
+	return nil
+}
+
+// SetDefaults_InitConfiguration assigns default values for the InitConfiguration.
+func SetDefaults_InitConfiguration(obj *InitConfiguration) {
+	if obj.LocalAPIEndpoint.BindPort == 0 {
+		obj.LocalAPIEndpoint.BindPort = DefaultAPIBindPort
+	}
+	for i := range obj.BootstrapTokens {
+		if obj.BootstrapTokens[i].TTL == nil {
+			obj.BootstrapTokens[i].TTL = &metav1.Duration{Duration: DefaultTokenDuration}
+		}
+	}
+}
+
+// SetDefaults_ClusterConfiguration assigns default values for the ClusterConfiguration.
+func SetDefaults_ClusterConfiguration(obj *ClusterConfiguration) {
+	if obj.KubernetesVersion == "" {
+		obj.KubernetesVersion = "stable"
+	}
+	if obj.Networking.ServiceSubnet == "" {
+		obj.Networking.ServiceSubnet = DefaultServicesSubnet
+	}
+	if obj.Networking.DNSDomain == "" {
+		obj.Networking.DNSDomain = DefaultServiceDNSDomain
+	}
+	if obj.CertificatesDir == "" {
+		obj.CertificatesDir = DefaultCertificatesDir
+	}
+	if obj.ImageRepository == "" {
+		obj.ImageRepository = DefaultImageRepository
+	}
+}
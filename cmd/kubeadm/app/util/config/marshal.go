@@ -0,0 +1,59 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
+	kubeadmapiv1alpha3 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1alpha3"
+	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+)
+
+// MarshalInitConfigurationToYAML converts cfg to gv and marshals it to YAML. v1alpha3 keeps the
+// node-local and cluster-wide settings in a single MasterConfiguration document, so that half
+// needs its own conversion call before the cluster half is folded into the same object. v1alpha2
+// registers no conversions and cannot be written by this function. v1beta1 and the internal API keep InitConfiguration and ClusterConfiguration as distinct
+// Kinds, so kubeadmutil.MarshalToYamlForCodecs already produces the right document for each half on
+// its own; those are joined into the two "---"-separated documents kubeadm writes to disk.
+func MarshalInitConfigurationToYAML(cfg *kubeadmapi.InitConfiguration, gv schema.GroupVersion) ([]byte, error) {
+	if gv == kubeadmapiv1alpha3.SchemeGroupVersion {
+		master := &kubeadmapiv1alpha3.MasterConfiguration{}
+		if err := scheme.Scheme.Convert(cfg, master, nil); err != nil {
+			return nil, err
+		}
+		if err := scheme.Scheme.Convert(&cfg.ClusterConfiguration, master, nil); err != nil {
+			return nil, err
+		}
+		return kubeadmutil.MarshalToYamlForCodecs(master, gv, scheme.Codecs)
+	}
+
+	initBytes, err := kubeadmutil.MarshalToYamlForCodecs(cfg, gv, scheme.Codecs)
+	if err != nil {
+		return nil, err
+	}
+	clusterBytes, err := kubeadmutil.MarshalToYamlForCodecs(&cfg.ClusterConfiguration, gv, scheme.Codecs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := append([]byte{}, initBytes...)
+	out = append(out, []byte("---\n")...)
+	out = append(out, clusterBytes...)
+	return out, nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config implements the loading, defaulting and converting of kubeadm's on-disk
+// configuration file into the internal kubeadm API that the rest of kubeadm consumes.
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
+	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+)
+
+// componentConfigKinds are Kinds that kubeadm has no registered internal type for, but that are
+// commonly shipped alongside a kubeadm config document in the same file (for example a
+// KubeletConfiguration document appended to a kubeadm config). Rather than rejecting
+// them, the loader stashes the raw document on ClusterConfiguration.ComponentConfigs, keyed by
+// Kind, so callers that do understand them (kubelet/kube-proxy phases) can still get at them.
+var componentConfigKinds = map[string]bool{
+	"KubeletConfiguration":   true,
+	"KubeProxyConfiguration": true,
+}
+
+// ConfigFileAndDefaultsToInternalConfig reads the given configuration file, which may contain
+// several "---"-separated YAML documents, and converts it into the internal InitConfiguration type
+// used throughout the rest of kubeadm.
+//
+// Every document belonging to the kubeadm.k8s.io API group is checked against
+// ValidateSupportedVersion, then decoded and merged into the returned InitConfiguration. A legacy,
+// pre-v1beta1 MasterConfiguration document sets both the node-local and cluster-wide halves at
+// once; v1beta1 and later instead ship InitConfiguration and ClusterConfiguration as two separate
+// documents. Seeing either half more than once is an error. Documents for well-known component
+// configs (see componentConfigKinds) are kept, unparsed, on the returned object's ComponentConfigs
+// field. Any other document is an error, since kubeadm has no way to know what to do with it.
+//
+// If cfgPath is empty, no file is read and defaultversionedcfg (already populated from command line
+// flags, for example) is defaulted and converted instead; it is expected to be shaped like a legacy
+// MasterConfiguration, i.e. carry both halves in one object.
+//
+// If, after defaulting, KubernetesVersion is still empty or is a version channel alias such as
+// "stable" or "latest", it is resolved to a concrete version via DetectServerVersion.
+func ConfigFileAndDefaultsToInternalConfig(cfgPath string, defaultversionedcfg runtime.Object) (*kubeadmapi.InitConfiguration, error) {
+	internalcfg := &kubeadmapi.InitConfiguration{}
+
+	if cfgPath != "" {
+		b, err := ioutil.ReadFile(cfgPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read config from %q: %v", cfgPath, err)
+		}
+
+		if err := documentsToInternalConfig(b, internalcfg); err != nil {
+			return nil, err
+		}
+	} else {
+		scheme.Scheme.Default(defaultversionedcfg)
+		if err := scheme.Scheme.Convert(defaultversionedcfg, internalcfg, nil); err != nil {
+			return nil, err
+		}
+		if err := scheme.Scheme.Convert(defaultversionedcfg, &internalcfg.ClusterConfiguration, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	scheme.Scheme.Default(internalcfg)
+	scheme.Scheme.Default(&internalcfg.ClusterConfiguration)
+
+	if internalcfg.ClusterConfiguration.KubernetesVersion == "" || isChannelAlias(internalcfg.ClusterConfiguration.KubernetesVersion) {
+		info, err := DetectServerVersion(defaultAdminKubeConfigPath, internalcfg.ClusterConfiguration.KubernetesVersion)
+		if err != nil {
+			return nil, err
+		}
+		internalcfg.ClusterConfiguration.KubernetesVersion = info.GitVersion
+	}
+
+	LowercaseSANs(internalcfg.ClusterConfiguration.APIServerCertSANs)
+	return internalcfg, nil
+}
+
+// defaultAdminKubeConfigPath is where DetectServerVersion looks for a kubeconfig when loading a
+// config file; by the time kubeadm needs to resolve a version channel alias, the control-plane
+// node it's running on already has its own admin kubeconfig written out here.
+const defaultAdminKubeConfigPath = "/etc/kubernetes/admin.conf"
+
+// documentsToInternalConfig splits fileBytes on "---" document boundaries, decodes each document
+// by its apiVersion/kind, merges the kubeadm.k8s.io ones into internalcfg, and stashes component
+// config documents on internalcfg.ComponentConfigs.
+func documentsToInternalConfig(fileBytes []byte, internalcfg *kubeadmapi.InitConfiguration) error {
+	seenInit := false
+	seenCluster := false
+
+	for _, doc := range kubeadmutil.SplitYAMLDocuments(fileBytes) {
+		gvk, err := kubeadmutil.GroupVersionKindFromBytes(doc, scheme.Codecs)
+		if err != nil {
+			return fmt.Errorf("unable to decode config document: %v", err)
+		}
+
+		switch {
+		case gvk.Group == kubeadmapi.GroupName:
+			// kubeadm only reads the current API version and the one before it (N-1); see
+			// ValidateSupportedVersion for the exact matrix.
+			if err := ValidateSupportedVersion(gvk.GroupVersion(), true); err != nil {
+				return err
+			}
+
+			obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(doc, nil, nil)
+			if err != nil {
+				return fmt.Errorf("unable to decode %s: %v", gvk.Kind, err)
+			}
+			scheme.Scheme.Default(obj)
+
+			switch gvk.Kind {
+			case "MasterConfiguration":
+				// A legacy, pre-v1beta1 MasterConfiguration sets both halves of the internal
+				// config from the one document.
+				if seenInit || seenCluster {
+					return fmt.Errorf("config file contains more than one document configuring the node or the cluster")
+				}
+				if err := scheme.Scheme.Convert(obj, internalcfg, nil); err != nil {
+					return fmt.Errorf("unable to convert %s: %v", gvk.Kind, err)
+				}
+				if err := scheme.Scheme.Convert(obj, &internalcfg.ClusterConfiguration, nil); err != nil {
+					return fmt.Errorf("unable to convert %s: %v", gvk.Kind, err)
+				}
+				seenInit, seenCluster = true, true
+
+			case "InitConfiguration":
+				if seenInit {
+					return fmt.Errorf("config file contains more than one document of kind %q", gvk.Kind)
+				}
+				if err := scheme.Scheme.Convert(obj, internalcfg, nil); err != nil {
+					return fmt.Errorf("unable to convert %s: %v", gvk.Kind, err)
+				}
+				seenInit = true
+
+			case "ClusterConfiguration":
+				if seenCluster {
+					return fmt.Errorf("config file contains more than one document of kind %q", gvk.Kind)
+				}
+				if err := scheme.Scheme.Convert(obj, &internalcfg.ClusterConfiguration, nil); err != nil {
+					return fmt.Errorf("unable to convert %s: %v", gvk.Kind, err)
+				}
+				seenCluster = true
+
+			default:
+				return fmt.Errorf("unknown kubeadm.k8s.io kind %q", gvk.Kind)
+			}
+
+		case componentConfigKinds[gvk.Kind]:
+			if internalcfg.ComponentConfigs == nil {
+				internalcfg.ComponentConfigs = kubeadmapi.ComponentConfigMap{}
+			}
+			if _, ok := internalcfg.ComponentConfigs[gvk.Kind]; ok {
+				return fmt.Errorf("config file contains more than one document of kind %q", gvk.Kind)
+			}
+			internalcfg.ComponentConfigs[gvk.Kind] = runtime.RawExtension{Raw: append([]byte(nil), doc...)}
+
+		default:
+			return fmt.Errorf("unknown configuration %s", gvk.String())
+		}
+	}
+
+	if !seenInit || !seenCluster {
+		return fmt.Errorf("config file must contain both a node-local configuration (InitConfiguration or MasterConfiguration) and a ClusterConfiguration")
+	}
+	return nil
+}
+
+// LowercaseSANs can be used to force all SANs to be lowercase so it passes the regex validation.
+// Note that this is not the case for the primary hostname, but for the SANs given via the
+// --apiserver-cert-extra-sans flag, which are already lowercase via this function.
+func LowercaseSANs(sans []string) {
+	for i, san := range sans {
+		lowercase := strings.ToLower(san)
+		if lowercase != san {
+			fmt.Printf("[config] lowercasing SAN %q to %q\n", san, lowercase)
+			sans[i] = lowercase
+		}
+	}
+}
@@ -17,117 +17,270 @@ limitations under the License.
 package config
 
 import (
-	"bytes"
-	"io/ioutil"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/pmezard/go-difflib/difflib"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
 	kubeadmapiv1alpha3 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1alpha3"
-	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+	kubeadmapiv1beta1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta1"
 )
 
 const (
+	master_v1alpha1YAML   = "testdata/conversion/master/v1alpha1.yaml"
 	master_v1alpha2YAML   = "testdata/conversion/master/v1alpha2.yaml"
 	master_v1alpha3YAML   = "testdata/conversion/master/v1alpha3.yaml"
-	master_internalYAML   = "testdata/conversion/master/internal.yaml"
+	master_v1beta1YAML    = "testdata/conversion/master/v1beta1.yaml"
 	master_incompleteYAML = "testdata/defaulting/master/incomplete.yaml"
-	master_defaultedYAML  = "testdata/defaulting/master/defaulted.yaml"
 	master_invalidYAML    = "testdata/validation/invalid_mastercfg.yaml"
 )
 
-func diff(expected, actual []byte) string {
-	// Write out the diff
-	var diffBytes bytes.Buffer
-	difflib.WriteUnifiedDiff(&diffBytes, difflib.UnifiedDiff{
-		A:        difflib.SplitLines(string(expected)),
-		B:        difflib.SplitLines(string(actual)),
-		FromFile: "expected",
-		ToFile:   "actual",
-		Context:  3,
-	})
-	return diffBytes.String()
+// expectedInternalConfig is what both master_v1alpha3YAML and master_v1beta1YAML are expected to
+// decode to; the two fixtures describe the same config in two different API versions.
+func expectedInternalConfig() *kubeadm.InitConfiguration {
+	return &kubeadm.InitConfiguration{
+		BootstrapTokens: []kubeadm.BootstrapToken{
+			{
+				Token:  "abcdef.0123456789abcdef",
+				TTL:    &metav1.Duration{Duration: 24 * time.Hour},
+				Usages: []string{"signing", "authentication"},
+			},
+		},
+		NodeRegistration: kubeadm.NodeRegistrationOptions{Name: "node-01"},
+		LocalAPIEndpoint: kubeadm.APIEndpoint{AdvertiseAddress: "1.2.3.4", BindPort: 6443},
+		ClusterConfiguration: kubeadm.ClusterConfiguration{
+			ClusterName:       "kubernetes",
+			KubernetesVersion: "v1.13.0",
+			APIServerCertSANs: []string{"example.k8s.io"},
+			CertificatesDir:   "/etc/kubernetes/pki",
+			ImageRepository:   "k8s.gcr.io",
+			Etcd:              kubeadm.Etcd{Local: &kubeadm.LocalEtcd{DataDir: "/var/lib/etcd"}},
+			Networking:        kubeadm.Networking{DNSDomain: "cluster.local", ServiceSubnet: "10.96.0.0/12"},
+		},
+	}
 }
 
+// TestConfigFileAndDefaultsToInternalConfig makes sure ConfigFileAndDefaultsToInternalConfig, which
+// all of kubeadm uses to unmarshal its config file, correctly decodes and defaults each supported
+// API version into the internal type.
 func TestConfigFileAndDefaultsToInternalConfig(t *testing.T) {
-	var tests = []struct {
-		name, in, out string
-		groupVersion  schema.GroupVersion
-		expectedErr   bool
+	for _, rt := range []struct {
+		name string
+		in   string
 	}{
-		// These tests are reading one file, loading it using ConfigFileAndDefaultsToInternalConfig that all of kubeadm is using for unmarshal of our API types,
-		// and then marshals the internal object to the expected groupVersion
-		{ // v1alpha2 -> internal
-			name:         "v1alpha2ToInternal",
-			in:           master_v1alpha2YAML,
-			out:          master_internalYAML,
-			groupVersion: kubeadm.SchemeGroupVersion,
-		},
-		{ // v1alpha3 -> internal
-			name:         "v1alpha3ToInternal",
-			in:           master_v1alpha3YAML,
-			out:          master_internalYAML,
-			groupVersion: kubeadm.SchemeGroupVersion,
-		},
-		{ // v1alpha2 -> internal -> v1alpha3
-			name:         "v1alpha2Tov1alpha3",
-			in:           master_v1alpha2YAML,
-			out:          master_v1alpha3YAML,
-			groupVersion: kubeadmapiv1alpha3.SchemeGroupVersion,
-		},
-		{ // v1alpha3 -> internal -> v1alpha3
-			name:         "v1alpha3Tov1alpha3",
-			in:           master_v1alpha3YAML,
-			out:          master_v1alpha3YAML,
-			groupVersion: kubeadmapiv1alpha3.SchemeGroupVersion,
-		},
-		// These tests are reading one file that has only a subset of the fields populated, loading it using ConfigFileAndDefaultsToInternalConfig,
-		// and then marshals the internal object to the expected groupVersion
-		{ // v1alpha2 -> default -> validate -> internal -> v1alpha3
-			name:         "incompleteYAMLToDefaultedv1alpha2",
-			in:           master_incompleteYAML,
-			out:          master_defaultedYAML,
-			groupVersion: kubeadmapiv1alpha3.SchemeGroupVersion,
-		},
-		{ // v1alpha2 -> validation should fail
-			name:        "invalidYAMLShouldFail",
-			in:          master_invalidYAML,
-			expectedErr: true,
-		},
+		{name: "v1alpha3ToInternal", in: master_v1alpha3YAML},
+		{name: "v1beta1ToInternal", in: master_v1beta1YAML},
+	} {
+		t.Run(rt.name, func(t *testing.T) {
+			internalcfg, err := ConfigFileAndDefaultsToInternalConfig(rt.in, &kubeadmapiv1alpha3.MasterConfiguration{})
+			if err != nil {
+				t.Fatalf("couldn't unmarshal test data: %v", err)
+			}
+
+			if !reflect.DeepEqual(internalcfg, expectedInternalConfig()) {
+				t.Errorf("expected:\n%#v\ngot:\n%#v", expectedInternalConfig(), internalcfg)
+			}
+		})
 	}
+}
+
+// TestConfigFileAndDefaultsToInternalConfigRoundTrip makes sure the internal config produced from
+// one API version can be marshaled back out to any supported API version and decoded into the
+// fields that version is expected to carry.
+func TestConfigFileAndDefaultsToInternalConfigRoundTrip(t *testing.T) {
+	t.Run("v1alpha3Tov1alpha3", func(t *testing.T) {
+		internalcfg, err := ConfigFileAndDefaultsToInternalConfig(master_v1alpha3YAML, &kubeadmapiv1alpha3.MasterConfiguration{})
+		if err != nil {
+			t.Fatalf("couldn't unmarshal test data: %v", err)
+		}
 
-	for _, rt := range tests {
-		t.Run(rt.name, func(t2 *testing.T) {
+		master := decodeV1alpha3MasterConfiguration(t, internalcfg)
+		expected := &kubeadmapiv1alpha3.MasterConfiguration{
+			BootstrapTokens: []kubeadmapiv1alpha3.BootstrapToken{
+				{
+					Token:  "abcdef.0123456789abcdef",
+					TTL:    &metav1.Duration{Duration: 24 * time.Hour},
+					Usages: []string{"signing", "authentication"},
+				},
+			},
+			NodeRegistration:  kubeadmapiv1alpha3.NodeRegistrationOptions{Name: "node-01"},
+			LocalAPIEndpoint:  kubeadmapiv1alpha3.APIEndpoint{AdvertiseAddress: "1.2.3.4", BindPort: 6443},
+			ClusterName:       "kubernetes",
+			KubernetesVersion: "v1.13.0",
+			APIServerCertSANs: []string{"example.k8s.io"},
+			CertificatesDir:   "/etc/kubernetes/pki",
+			ImageRepository:   "k8s.gcr.io",
+			Etcd:              kubeadmapiv1alpha3.Etcd{Local: &kubeadmapiv1alpha3.LocalEtcd{DataDir: "/var/lib/etcd"}},
+			Networking:        kubeadmapiv1alpha3.Networking{DNSDomain: "cluster.local", ServiceSubnet: "10.96.0.0/12"},
+		}
+		if !reflect.DeepEqual(master, expected) {
+			t.Errorf("expected:\n%#v\ngot:\n%#v", expected, master)
+		}
+	})
 
+	for _, rt := range []struct {
+		name string
+		in   string
+	}{
+		{name: "v1alpha3Tov1beta1", in: master_v1alpha3YAML},
+		{name: "v1beta1Tov1beta1", in: master_v1beta1YAML},
+	} {
+		t.Run(rt.name, func(t *testing.T) {
 			internalcfg, err := ConfigFileAndDefaultsToInternalConfig(rt.in, &kubeadmapiv1alpha3.MasterConfiguration{})
 			if err != nil {
-				if rt.expectedErr {
-					return
-				}
-				t2.Fatalf("couldn't unmarshal test data: %v", err)
+				t.Fatalf("couldn't unmarshal test data: %v", err)
 			}
 
-			actual, err := kubeadmutil.MarshalToYamlForCodecs(internalcfg, rt.groupVersion, scheme.Codecs)
-			if err != nil {
-				t2.Fatalf("couldn't marshal internal object: %v", err)
+			initcfg, clustercfg := decodeV1beta1Documents(t, internalcfg)
+
+			expectedInit := &kubeadmapiv1beta1.InitConfiguration{
+				BootstrapTokens: []kubeadmapiv1beta1.BootstrapToken{
+					{
+						Token:  "abcdef.0123456789abcdef",
+						TTL:    &metav1.Duration{Duration: 24 * time.Hour},
+						Usages: []string{"signing", "authentication"},
+					},
+				},
+				NodeRegistration: kubeadmapiv1beta1.NodeRegistrationOptions{Name: "node-01"},
+				LocalAPIEndpoint: kubeadmapiv1beta1.APIEndpoint{AdvertiseAddress: "1.2.3.4", BindPort: 6443},
+			}
+			if !reflect.DeepEqual(initcfg, expectedInit) {
+				t.Errorf("expected:\n%#v\ngot:\n%#v", expectedInit, initcfg)
 			}
 
-			expected, err := ioutil.ReadFile(rt.out)
-			if err != nil {
-				t2.Fatalf("couldn't read test data: %v", err)
+			expectedCluster := &kubeadmapiv1beta1.ClusterConfiguration{
+				ClusterName:       "kubernetes",
+				KubernetesVersion: "v1.13.0",
+				APIServerCertSANs: []string{"example.k8s.io"},
+				CertificatesDir:   "/etc/kubernetes/pki",
+				ImageRepository:   "k8s.gcr.io",
+				Etcd:              kubeadmapiv1beta1.Etcd{Local: &kubeadmapiv1beta1.LocalEtcd{DataDir: "/var/lib/etcd"}},
+				Networking:        kubeadmapiv1beta1.Networking{DNSDomain: "cluster.local", ServiceSubnet: "10.96.0.0/12"},
 			}
+			if !reflect.DeepEqual(clustercfg, expectedCluster) {
+				t.Errorf("expected:\n%#v\ngot:\n%#v", expectedCluster, clustercfg)
+			}
+		})
+	}
 
-			if !bytes.Equal(expected, actual) {
-				t2.Errorf("the expected and actual output differs.\n\tin: %s\n\tout: %s\n\tgroupversion: %s\n\tdiff: \n%s\n",
-					rt.in, rt.out, rt.groupVersion.String(), diff(expected, actual))
+	t.Run("incompleteYAMLToDefaultedv1alpha3", func(t *testing.T) {
+		internalcfg, err := ConfigFileAndDefaultsToInternalConfig(master_incompleteYAML, &kubeadmapiv1alpha3.MasterConfiguration{})
+		if err != nil {
+			t.Fatalf("couldn't unmarshal test data: %v", err)
+		}
+
+		master := decodeV1alpha3MasterConfiguration(t, internalcfg)
+		expected := &kubeadmapiv1alpha3.MasterConfiguration{
+			NodeRegistration:  kubeadmapiv1alpha3.NodeRegistrationOptions{Name: "node-01"},
+			LocalAPIEndpoint:  kubeadmapiv1alpha3.APIEndpoint{AdvertiseAddress: "1.2.3.4", BindPort: 6443},
+			KubernetesVersion: "v1.13.0",
+			CertificatesDir:   "/etc/kubernetes/pki",
+			ImageRepository:   "k8s.gcr.io",
+			Networking:        kubeadmapiv1alpha3.Networking{DNSDomain: "cluster.local", ServiceSubnet: "10.96.0.0/12"},
+		}
+		if !reflect.DeepEqual(master, expected) {
+			t.Errorf("expected:\n%#v\ngot:\n%#v", expected, master)
+		}
+	})
+}
+
+func TestConfigFileAndDefaultsToInternalConfigErrors(t *testing.T) {
+	for _, rt := range []struct {
+		name string
+		in   string
+	}{
+		{name: "invalidYAMLShouldFail", in: master_invalidYAML},
+		// kubeadm only supports the current and the N-1 API version; v1alpha1 predates that policy
+		// entirely and is rejected by ValidateSupportedVersion before the document is decoded.
+		{name: "v1alpha1ShouldFail", in: master_v1alpha1YAML},
+		// v1alpha2 is now out of the supported N-1 window and should be hard-rejected, even though
+		// the type is still registered so that "kubeadm config migrate" can read it.
+		{name: "v1alpha2ShouldFail", in: master_v1alpha2YAML},
+	} {
+		t.Run(rt.name, func(t *testing.T) {
+			if _, err := ConfigFileAndDefaultsToInternalConfig(rt.in, &kubeadmapiv1alpha3.MasterConfiguration{}); err == nil {
+				t.Errorf("expected an error loading %q, got none", rt.in)
 			}
 		})
 	}
 }
 
+// decodeV1alpha3MasterConfiguration marshals internalcfg to v1alpha3 and decodes the result back
+// into a v1alpha3.MasterConfiguration, with TypeMeta cleared so callers can compare the rest of the
+// struct directly.
+func decodeV1alpha3MasterConfiguration(t *testing.T, internalcfg *kubeadm.InitConfiguration) *kubeadmapiv1alpha3.MasterConfiguration {
+	t.Helper()
+
+	out, err := MarshalInitConfigurationToYAML(internalcfg, kubeadmapiv1alpha3.SchemeGroupVersion)
+	if err != nil {
+		t.Fatalf("couldn't marshal internal object: %v", err)
+	}
+
+	obj, gvk, err := scheme.Codecs.UniversalDeserializer().Decode(out, nil, nil)
+	if err != nil {
+		t.Fatalf("couldn't decode marshaled output: %v", err)
+	}
+	if gvk.GroupVersion() != kubeadmapiv1alpha3.SchemeGroupVersion || gvk.Kind != "MasterConfiguration" {
+		t.Fatalf("expected a v1alpha3 MasterConfiguration, got %s", gvk.String())
+	}
+
+	master, ok := obj.(*kubeadmapiv1alpha3.MasterConfiguration)
+	if !ok {
+		t.Fatalf("expected *v1alpha3.MasterConfiguration, got %T", obj)
+	}
+	master.TypeMeta = metav1.TypeMeta{}
+	return master
+}
+
+// decodeV1beta1Documents marshals internalcfg to v1beta1 and decodes the resulting two
+// "---"-joined documents back into InitConfiguration and ClusterConfiguration, with TypeMeta
+// cleared so callers can compare the rest of each struct directly.
+func decodeV1beta1Documents(t *testing.T, internalcfg *kubeadm.InitConfiguration) (*kubeadmapiv1beta1.InitConfiguration, *kubeadmapiv1beta1.ClusterConfiguration) {
+	t.Helper()
+
+	out, err := MarshalInitConfigurationToYAML(internalcfg, kubeadmapiv1beta1.SchemeGroupVersion)
+	if err != nil {
+		t.Fatalf("couldn't marshal internal object: %v", err)
+	}
+
+	docs := strings.SplitN(string(out), "---\n", 2)
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents in marshaled output, got %d", len(docs))
+	}
+
+	initObj, initGVK, err := scheme.Codecs.UniversalDeserializer().Decode([]byte(docs[0]), nil, nil)
+	if err != nil {
+		t.Fatalf("couldn't decode the InitConfiguration document: %v", err)
+	}
+	if initGVK.GroupVersion() != kubeadmapiv1beta1.SchemeGroupVersion || initGVK.Kind != "InitConfiguration" {
+		t.Fatalf("expected a v1beta1 InitConfiguration, got %s", initGVK.String())
+	}
+	initcfg, ok := initObj.(*kubeadmapiv1beta1.InitConfiguration)
+	if !ok {
+		t.Fatalf("expected *v1beta1.InitConfiguration, got %T", initObj)
+	}
+	initcfg.TypeMeta = metav1.TypeMeta{}
+
+	clusterObj, clusterGVK, err := scheme.Codecs.UniversalDeserializer().Decode([]byte(docs[1]), nil, nil)
+	if err != nil {
+		t.Fatalf("couldn't decode the ClusterConfiguration document: %v", err)
+	}
+	if clusterGVK.GroupVersion() != kubeadmapiv1beta1.SchemeGroupVersion || clusterGVK.Kind != "ClusterConfiguration" {
+		t.Fatalf("expected a v1beta1 ClusterConfiguration, got %s", clusterGVK.String())
+	}
+	clustercfg, ok := clusterObj.(*kubeadmapiv1beta1.ClusterConfiguration)
+	if !ok {
+		t.Fatalf("expected *v1beta1.ClusterConfiguration, got %T", clusterObj)
+	}
+	clustercfg.TypeMeta = metav1.TypeMeta{}
+
+	return initcfg, clustercfg
+}
+
 func TestLowercaseSANs(t *testing.T) {
 	tests := []struct {
 		name string
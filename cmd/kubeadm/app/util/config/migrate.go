@@ -0,0 +1,60 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
+	kubeadmapiv1alpha2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1alpha2"
+	kubeadmapiv1beta1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta1"
+)
+
+// MigrateOldConfig reads a single legacy kubeadm.k8s.io/v1alpha2 MasterConfiguration document and
+// converts it to the current API version, emitting the result as the two "---"-joined
+// InitConfiguration/ClusterConfiguration documents that "kubeadm config migrate" writes out.
+func MigrateOldConfig(oldConfig []byte) ([]byte, error) {
+	oldcfg := &kubeadmapiv1alpha2.MasterConfiguration{}
+	if err := runtimeDecodeInto(oldConfig, oldcfg); err != nil {
+		return nil, fmt.Errorf("unable to decode config as a kubeadm.k8s.io/v1alpha2 MasterConfiguration: %v", err)
+	}
+
+	scheme.Scheme.Default(oldcfg)
+
+	internalcfg := &kubeadmapi.InitConfiguration{}
+	if err := kubeadmapiv1alpha2.MasterConfigurationToInitConfiguration(oldcfg, internalcfg, nil); err != nil {
+		return nil, err
+	}
+	if err := kubeadmapiv1alpha2.MasterConfigurationToClusterConfiguration(oldcfg, &internalcfg.ClusterConfiguration, nil); err != nil {
+		return nil, err
+	}
+
+	scheme.Scheme.Default(internalcfg)
+	scheme.Scheme.Default(&internalcfg.ClusterConfiguration)
+	LowercaseSANs(internalcfg.ClusterConfiguration.APIServerCertSANs)
+
+	return MarshalInitConfigurationToYAML(internalcfg, kubeadmapiv1beta1.SchemeGroupVersion)
+}
+
+// runtimeDecodeInto decodes a single kubeadm.k8s.io document into into, verifying that the
+// document really is the Kind into expects.
+func runtimeDecodeInto(doc []byte, into runtime.Object) error {
+	return runtime.DecodeInto(scheme.Codecs.UniversalDecoder(), doc, into)
+}
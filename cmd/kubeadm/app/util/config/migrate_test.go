@@ -0,0 +1,67 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// TestMigrateOldConfig makes sure a legacy v1alpha2 MasterConfiguration document is migrated to a
+// current, two-document v1beta1 InitConfiguration/ClusterConfiguration config, with its bootstrap
+// token and node registration fields carried over correctly.
+func TestMigrateOldConfig(t *testing.T) {
+	oldConfig, err := ioutil.ReadFile(master_v1alpha2YAML)
+	if err != nil {
+		t.Fatalf("couldn't read test data: %v", err)
+	}
+
+	newConfig, err := MigrateOldConfig(oldConfig)
+	if err != nil {
+		t.Fatalf("couldn't migrate old config: %v", err)
+	}
+
+	docs := strings.Split(string(newConfig), "---\n")
+	if len(docs) != 2 {
+		t.Fatalf("expected migrated config to contain 2 documents, got %d", len(docs))
+	}
+
+	if !strings.Contains(docs[0], "kind: InitConfiguration") {
+		t.Errorf("expected the first migrated document to be an InitConfiguration, got:\n%s", docs[0])
+	}
+	if !strings.Contains(docs[0], "name: node-01") {
+		t.Errorf("expected the migrated InitConfiguration to carry over the node registration name, got:\n%s", docs[0])
+	}
+
+	if !strings.Contains(docs[1], "kind: ClusterConfiguration") {
+		t.Errorf("expected the second migrated document to be a ClusterConfiguration, got:\n%s", docs[1])
+	}
+	if !strings.Contains(docs[1], "apiVersion: kubeadm.k8s.io/v1beta1") {
+		t.Errorf("expected the migrated ClusterConfiguration to be v1beta1, got:\n%s", docs[1])
+	}
+	if !strings.Contains(docs[1], "example.k8s.io") {
+		t.Errorf("expected the migrated ClusterConfiguration to carry over a lowercased SAN, got:\n%s", docs[1])
+	}
+}
+
+// TestMigrateOldConfigInvalid makes sure a non-v1alpha2 document is rejected.
+func TestMigrateOldConfigInvalid(t *testing.T) {
+	if _, err := MigrateOldConfig([]byte("apiVersion: kubeadm.k8s.io/v1beta1\nkind: InitConfiguration\n")); err == nil {
+		t.Error("expected an error migrating a non-v1alpha2 document, got none")
+	}
+}
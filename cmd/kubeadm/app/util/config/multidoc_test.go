@@ -0,0 +1,80 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	kubeadmapiv1alpha3 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1alpha3"
+)
+
+const (
+	multidocYAML              = "testdata/conversion/master/multidoc.yaml"
+	multidocDuplicateKindYAML = "testdata/conversion/master/multidoc_duplicate_kind.yaml"
+	multidocUnknownGVKYAML    = "testdata/conversion/master/multidoc_unknown_gvk.yaml"
+)
+
+// TestConfigFileAndDefaultsToInternalConfigMultiDoc makes sure a config file made up of a
+// MasterConfiguration document plus one or more component-config documents round-trips: the
+// kubeadm document is merged into the internal config as usual, and the rest show up, untouched,
+// on ComponentConfigs.
+func TestConfigFileAndDefaultsToInternalConfigMultiDoc(t *testing.T) {
+	internalcfg, err := ConfigFileAndDefaultsToInternalConfig(multidocYAML, &kubeadmapiv1alpha3.MasterConfiguration{})
+	if err != nil {
+		t.Fatalf("couldn't unmarshal multi-document test data: %v", err)
+	}
+
+	if internalcfg.NodeRegistration.Name != "node-01" {
+		t.Errorf("expected NodeRegistration.Name %q, got %q", "node-01", internalcfg.NodeRegistration.Name)
+	}
+
+	if len(internalcfg.ComponentConfigs) != 2 {
+		t.Fatalf("expected 2 component configs, got %d", len(internalcfg.ComponentConfigs))
+	}
+	for _, kind := range []string{"KubeletConfiguration", "KubeProxyConfiguration"} {
+		cc, ok := internalcfg.ComponentConfigs[kind]
+		if !ok {
+			t.Errorf("expected a %s component config to be preserved", kind)
+			continue
+		}
+		if !strings.Contains(string(cc.Raw), kind) {
+			t.Errorf("expected the preserved %s document to still contain its own kind", kind)
+		}
+	}
+}
+
+// TestConfigFileAndDefaultsToInternalConfigMultiDocErrors covers the error paths of a multi-document
+// config file: seeing the same kubeadm Kind twice, or a document kubeadm doesn't know what to do
+// with at all.
+func TestConfigFileAndDefaultsToInternalConfigMultiDocErrors(t *testing.T) {
+	var tests = []struct {
+		name string
+		in   string
+	}{
+		{name: "duplicateKind", in: multidocDuplicateKindYAML},
+		{name: "unknownGVK", in: multidocUnknownGVKYAML},
+	}
+
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			if _, err := ConfigFileAndDefaultsToInternalConfig(rt.in, &kubeadmapiv1alpha3.MasterConfiguration{}); err == nil {
+				t.Errorf("expected an error loading %q, got none", rt.in)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// kubeReleaseBucketURL is where kubeadm looks up release markers (e.g. "stable.txt",
+// "stable-1.13.txt", "latest.txt") when it has no other way to resolve a version channel alias.
+const kubeReleaseBucketURL = "https://dl.k8s.io"
+
+// execCommand is a var, rather than a direct call to exec.Command, so tests can substitute a fake
+// kubectl.
+var execCommand = exec.Command
+
+// cachedServerVersion memoizes DetectServerVersion for the lifetime of the process: a single
+// "kubeadm init"/"kubeadm join" run only ever talks to one cluster, so there's no reason to shell
+// out to kubectl, or hit the network, more than once.
+var cachedServerVersion *version.Info
+
+// isChannelAlias reports whether v is a version channel alias like "stable", "stable-1.13", or
+// "latest" that DetectServerVersion needs to resolve, rather than a concrete version like
+// "v1.13.0".
+func isChannelAlias(v string) bool {
+	return v == "stable" || v == "latest" || strings.HasPrefix(v, "stable-")
+}
+
+// DetectServerVersion returns the version of the Kubernetes apiserver that kubectl is configured
+// to reach, preferring "kubectl version -o json" and falling back to the dl.k8s.io release marker
+// named by channel (e.g. "stable", "stable-1.13", "latest") if kubectl can't be run or can't reach
+// a server. channel defaults to "stable" if empty. kubeconfig, if non-empty, is passed to kubectl
+// via --kubeconfig. The result is cached for the life of the process.
+func DetectServerVersion(kubeconfig, channel string) (*version.Info, error) {
+	if cachedServerVersion != nil {
+		return cachedServerVersion, nil
+	}
+
+	if channel == "" {
+		channel = "stable"
+	}
+
+	info, err := kubectlServerVersion(kubeconfig)
+	if err != nil {
+		info, err = releaseMarkerVersion(channel)
+		if err != nil {
+			return nil, fmt.Errorf("unable to detect the Kubernetes server version: %v", err)
+		}
+	}
+
+	cachedServerVersion = info
+	return info, nil
+}
+
+// kubectlVersionOutput is the subset of "kubectl version -o json" that kubeadm cares about.
+type kubectlVersionOutput struct {
+	ServerVersion *version.Info `json:"serverVersion"`
+}
+
+// kubectlServerVersion shells out to "kubectl version -o json" and parses the serverVersion field
+// out of its output. Newer kubectl releases print a "client-only" warning line before the JSON
+// document when they can't reach a server, and some print it on stdout rather than stderr;
+// scanning for the first "{" makes that harmless instead of breaking the JSON decode.
+func kubectlServerVersion(kubeconfig string) (*version.Info, error) {
+	args := []string{"version", "-o", "json"}
+	if kubeconfig != "" {
+		args = append(args, "--kubeconfig", kubeconfig)
+	}
+
+	out, err := execCommand("kubectl", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to run kubectl: %v", err)
+	}
+
+	start := bytes.IndexByte(out, '{')
+	if start == -1 {
+		return nil, fmt.Errorf("unable to find a JSON document in kubectl output: %q", out)
+	}
+
+	var parsed kubectlVersionOutput
+	if err := json.Unmarshal(out[start:], &parsed); err != nil {
+		return nil, fmt.Errorf("unable to parse kubectl output: %v", err)
+	}
+	if parsed.ServerVersion == nil || parsed.ServerVersion.GitVersion == "" {
+		return nil, fmt.Errorf("kubectl output did not include a server version, is the cluster reachable?")
+	}
+	return parsed.ServerVersion, nil
+}
+
+// releaseMarkerVersion fetches the named release marker (e.g. "stable", "stable-1.13", "latest")
+// from dl.k8s.io, returning the concrete version it points at.
+func releaseMarkerVersion(channel string) (*version.Info, error) {
+	url := fmt.Sprintf("%s/release/%s.txt", kubeReleaseBucketURL, channel)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch %q: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %q: %v", url, err)
+	}
+
+	gitVersion := strings.TrimSpace(string(body))
+	if gitVersion == "" {
+		return nil, fmt.Errorf("release marker %q was empty", url)
+	}
+	return &version.Info{GitVersion: gitVersion}, nil
+}
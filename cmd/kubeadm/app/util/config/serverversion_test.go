@@ -0,0 +1,160 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// TestMain seeds cachedServerVersion so that tests exercising ConfigFileAndDefaultsToInternalConfig
+// with an empty or aliased KubernetesVersion never shell out to a real kubectl or hit the network;
+// TestDetectServerVersionIsCached below replaces it for its own run and restores it afterwards.
+func TestMain(m *testing.M) {
+	cachedServerVersion = &version.Info{GitVersion: "v1.13.0"}
+	os.Exit(m.Run())
+}
+
+// fakeExecCommand returns an execCommand replacement that runs TestHelperProcess instead of a real
+// binary, writing out to stdout and exiting with the given code.
+func fakeExecCommand(output string, exitCode int) func(name string, args ...string) *exec.Cmd {
+	return func(name string, args ...string) *exec.Cmd {
+		cmd := exec.Command(os.Args[0], "-test.run=TestHelperProcess", "--")
+		cmd.Env = []string{
+			"GO_WANT_HELPER_PROCESS=1",
+			"HELPER_PROCESS_OUTPUT=" + output,
+			fmt.Sprintf("HELPER_PROCESS_EXIT=%d", exitCode),
+		}
+		return cmd
+	}
+}
+
+// TestHelperProcess is not a real test; it's the fake "kubectl" that fakeExecCommand's *exec.Cmd
+// re-invokes this same test binary as.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	fmt.Fprint(os.Stdout, os.Getenv("HELPER_PROCESS_OUTPUT"))
+	exitCode, _ := strconv.Atoi(os.Getenv("HELPER_PROCESS_EXIT"))
+	os.Exit(exitCode)
+}
+
+func TestKubectlServerVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		exitCode    int
+		expectGit   string
+		expectedErr bool
+	}{
+		{
+			name:      "well-formed JSON",
+			output:    `{"clientVersion":{"gitVersion":"v1.13.0"},"serverVersion":{"gitVersion":"v1.13.2"}}`,
+			expectGit: "v1.13.2",
+		},
+		{
+			// Some kubectl releases print a client-only warning on stdout ahead of the JSON
+			// document when they think the server might not be reachable; the parser needs to
+			// skip past it instead of failing the JSON decode.
+			name: "JSON preceded by a client-only warning",
+			output: "WARNING: This version information is incomplete due to an error:\n" +
+				"server could not find the requested resource\n" +
+				`{"clientVersion":{"gitVersion":"v1.13.0"},"serverVersion":{"gitVersion":"v1.13.2"}}`,
+			expectGit: "v1.13.2",
+		},
+		{
+			name:        "no server version in the output at all",
+			output:      `{"clientVersion":{"gitVersion":"v1.13.0"}}`,
+			expectedErr: true,
+		},
+		{
+			name:        "not JSON at all",
+			output:      "error: unable to connect to the server: dial tcp: i/o timeout\n",
+			expectedErr: true,
+		},
+		{
+			name:        "kubectl exits non-zero",
+			output:      "error: unable to connect to the server\n",
+			exitCode:    1,
+			expectedErr: true,
+		},
+	}
+
+	oldExecCommand := execCommand
+	defer func() { execCommand = oldExecCommand }()
+
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			execCommand = fakeExecCommand(rt.output, rt.exitCode)
+
+			info, err := kubectlServerVersion("")
+			if rt.expectedErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if info.GitVersion != rt.expectGit {
+				t.Errorf("expected GitVersion %q, got %q", rt.expectGit, info.GitVersion)
+			}
+		})
+	}
+}
+
+func TestIsChannelAlias(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"stable", true},
+		{"latest", true},
+		{"stable-1.13", true},
+		{"v1.13.0", false},
+		{"", false},
+	}
+
+	for _, rt := range tests {
+		if got := isChannelAlias(rt.version); got != rt.want {
+			t.Errorf("isChannelAlias(%q) = %v, want %v", rt.version, got, rt.want)
+		}
+	}
+}
+
+func TestDetectServerVersionIsCached(t *testing.T) {
+	oldCached := cachedServerVersion
+	defer func() { cachedServerVersion = oldCached }()
+
+	want := &version.Info{GitVersion: "v1.99.0"}
+	cachedServerVersion = want
+
+	got, err := DetectServerVersion("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Error("expected DetectServerVersion to return the cached version without recomputing it")
+	}
+}
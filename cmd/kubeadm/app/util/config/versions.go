@@ -0,0 +1,58 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+)
+
+// oldKubeadmGroupVersions are kubeadm.k8s.io versions that used to be readable but are no longer
+// supported at all; kubeadm only ever reads the current version and the one before it (N-1).
+var oldKubeadmGroupVersions = map[schema.GroupVersion]bool{
+	{Group: kubeadmapi.GroupName, Version: "v1alpha1"}: true,
+	{Group: kubeadmapi.GroupName, Version: "v1alpha2"}: true,
+}
+
+// deprecatedKubeadmGroupVersions are versions that are still read, but only when the caller opts
+// in to reading deprecated config (allowDeprecated); this is the "N-1" version.
+var deprecatedKubeadmGroupVersions = map[schema.GroupVersion]bool{
+	{Group: kubeadmapi.GroupName, Version: "v1alpha3"}: true,
+}
+
+// ValidateSupportedVersion checks if the supplied GroupVersion is supported by kubeadm, enforcing
+// the "only read the current and the N-1 API version" support policy. Versions older than N-1
+// always return an error. The N-1 version returns an error unless allowDeprecated is true, in
+// which case a warning is printed to stdout and nil is returned.
+func ValidateSupportedVersion(gv schema.GroupVersion, allowDeprecated bool) error {
+	if oldKubeadmGroupVersions[gv] {
+		return fmt.Errorf("your configuration file uses an old API spec: %q. Please use kubeadm v1.13 or later to generate a config file for the current version", gv.String())
+	}
+
+	if deprecatedKubeadmGroupVersions[gv] {
+		if allowDeprecated {
+			fmt.Printf("[config] WARNING: kubeadm config API spec %q is deprecated and will be removed in a future release. Use \"kubeadm config migrate\" to update your config file to a newer version\n", gv.String())
+			return nil
+		}
+		return fmt.Errorf("your configuration file uses a deprecated API spec: %q. Please use \"kubeadm config migrate\" to upgrade it to a newer, supported version", gv.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// yamlSeparator matches a line that only contains the YAML document separator "---", optionally
+// trailed by whitespace. Used to split a file that contains more than one document.
+var yamlSeparator = regexp.MustCompile(`(?m)^---[ \t]*$`)
+
+// MarshalToYamlForCodecs marshals an object into yaml using the specified codec and groupVersion.
+func MarshalToYamlForCodecs(obj runtime.Object, gv schema.GroupVersion, codecs serializer.CodecFactory) ([]byte, error) {
+	mediaType := "application/yaml"
+	info, ok := runtime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), mediaType)
+	if !ok {
+		return []byte{}, fmt.Errorf("unsupported media type %q", mediaType)
+	}
+
+	encoder := codecs.EncoderForVersion(info.Serializer, gv)
+	return runtime.Encode(encoder, obj)
+}
+
+// SplitYAMLDocuments reads the given bytes and splits them into the documents separated by "---"
+// lines, trimming empty documents produced by leading/trailing separators.
+func SplitYAMLDocuments(yamlBytes []byte) [][]byte {
+	docs := yamlSeparator.Split(string(yamlBytes), -1)
+	out := make([][]byte, 0, len(docs))
+	for _, doc := range docs {
+		trimmed := bytes.TrimSpace([]byte(doc))
+		if len(trimmed) == 0 {
+			continue
+		}
+		out = append(out, trimmed)
+	}
+	return out
+}
+
+// GroupVersionKindFromBytes parses the apiVersion/kind of a single YAML document without fully
+// decoding it, which lets callers pick the right registered type before unmarshaling. Unlike going
+// through codecs.UniversalDeserializer(), this does not require the document's Kind to be
+// registered in the given scheme, so it also works for documents (such as component configs) that
+// this package's Scheme knows nothing about.
+func GroupVersionKindFromBytes(rawDoc []byte, codecs serializer.CodecFactory) (*schema.GroupVersionKind, error) {
+	jsonDoc, err := utilyaml.ToJSON(rawDoc)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert document to JSON: %v", err)
+	}
+
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(jsonDoc, &typeMeta); err != nil {
+		return nil, fmt.Errorf("unable to parse apiVersion/kind: %v", err)
+	}
+
+	gvk := typeMeta.GroupVersionKind()
+	return &gvk, nil
+}